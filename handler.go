@@ -4,20 +4,35 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/sourcegraph/jsonrpc2"
 )
 
+// defaultDebounce is how long the server waits after the last didOpen/didSave
+// for a URI before actually invoking golangci-lint, so a burst of rapid saves
+// collapses into a single run.
+const defaultDebounce = 250 * time.Millisecond
+
 func NewHandler(logger logger, noLinterName bool) jsonrpc2.Handler {
 	handler := &langHandler{
 		logger:       logger,
-		request:      make(chan DocumentURI),
 		noLinterName: noLinterName,
+		debounce:     defaultDebounce,
+		lintMode:     LintModeWorkspace,
+		inflight:     make(map[DocumentURI]*pendingLint),
+		cancels:      make(map[jsonrpc2.ID]context.CancelFunc),
+		issues:       make(map[DocumentURI][]Issue),
+		published:    make(map[DocumentURI]bool),
+		buffers:      make(map[DocumentURI]*documentState),
 	}
-	go handler.linter()
 
 	return jsonrpc2.HandlerWithError(handler.handle)
 }
@@ -25,18 +40,226 @@ func NewHandler(logger logger, noLinterName bool) jsonrpc2.Handler {
 type langHandler struct {
 	logger       logger
 	conn         *jsonrpc2.Conn
-	request      chan DocumentURI
 	command      []string
 	noLinterName bool
+	debounce     time.Duration
+	lintMode     string
+	// incrementalSync opts into advertising TDSKIncremental and linting the
+	// in-memory buffer on every didChange instead of only on didSave.
+	incrementalSync bool
 
 	rootURI string
 	rootDir string
+
+	mu       sync.Mutex
+	inflight map[DocumentURI]*pendingLint
+	cancels  map[jsonrpc2.ID]context.CancelFunc
+	// issues caches, per URI, the most recent set of issues golangci-lint
+	// reported for that file, so textDocument/codeAction can offer quick
+	// fixes without re-running the linter.
+	issues map[DocumentURI][]Issue
+	// published tracks which URIs currently have non-empty diagnostics
+	// published from a workspace lint run, so a file that becomes clean can
+	// have its diagnostics cleared with an empty array.
+	published map[DocumentURI]bool
+	// buffers holds the in-memory, possibly-unsaved content of open
+	// documents when incrementalSync is enabled.
+	buffers map[DocumentURI]*documentState
+}
+
+// documentState is the in-memory buffer for one open document, kept in sync
+// with the editor via textDocument/didChange.
+type documentState struct {
+	text string
+}
+
+// LintMode controls whether golangci-lint is invoked per file (the
+// historical behavior) or once for the whole workspace, which also
+// surfaces issues golangci-lint reports against files other than the one
+// being edited (e.g. unused, deadcode).
+const (
+	LintModeFile      = "file"
+	LintModeWorkspace = "workspace"
+)
+
+// workspaceLintKey is the sentinel key used to debounce workspace-wide lint
+// runs in h.inflight, alongside the real per-URI entries.
+const workspaceLintKey DocumentURI = ""
+
+// pendingLint tracks the debounce timer and cancellation for the most
+// recently requested lint of a given URI, so a newer request can cancel
+// both the timer (if it hasn't fired yet) and the running golangci-lint
+// process (if it has).
+type pendingLint struct {
+	timer  *time.Timer
+	cancel context.CancelFunc
 }
 
 // As defined in the `golangci-lint` source code:
 // https://github.com/golangci/golangci-lint/blob/main/pkg/exitcodes/exitcodes.go#L24
 const GoNoFilesExitCode = 5
 
+// GolangCILintResult is the top-level shape of `golangci-lint run
+// --out-format json`.
+type GolangCILintResult struct {
+	Issues []Issue `json:"Issues"`
+}
+
+// Issue is a single golangci-lint finding, including the optional
+// Replacement golangci-lint computes when a linter supports --fix.
+type Issue struct {
+	FromLinter  string       `json:"FromLinter"`
+	Text        string       `json:"Text"`
+	Severity    string       `json:"Severity"`
+	SourceLines []string     `json:"SourceLines"`
+	Replacement *Replacement `json:"Replacement,omitempty"`
+	Pos         Pos          `json:"Pos"`
+	LineRange   *LineRange   `json:"LineRange,omitempty"`
+}
+
+type Pos struct {
+	Filename string `json:"Filename"`
+	Offset   int    `json:"Offset"`
+	Line     int    `json:"Line"`
+	Column   int    `json:"Column"`
+}
+
+// LineRange is the start/end line of a multi-line issue, present when the
+// finding isn't confined to a single line.
+type LineRange struct {
+	From int `json:"From"`
+	To   int `json:"To"`
+}
+
+// Replacement is golangci-lint's suggested fix for an issue: either an
+// inline, single-line substitution, or a set of lines that replace the
+// issue's full LineRange (or Pos.Line if LineRange is absent).
+type Replacement struct {
+	NeedOnlyDelete bool       `json:"NeedOnlyDelete"`
+	NewLines       []string   `json:"NewLines"`
+	Inline         *InlineFix `json:"Inline,omitempty"`
+}
+
+type InlineFix struct {
+	StartCol  int    `json:"StartCol"`
+	Length    int    `json:"Length"`
+	NewString string `json:"NewString"`
+}
+
+func (i *Issue) DiagSeverity() DiagnosticSeverity {
+	switch i.Severity {
+	case "error":
+		return DSError
+	case "warning":
+		return DSWarning
+	case "info":
+		return DSInfo
+	case "hint":
+		return DSHint
+	default:
+		return DSError
+	}
+}
+
+// linterDocsURL maps a golangci-lint linter name to the documentation page
+// for its rules, used to populate Diagnostic.CodeDescription.
+var linterDocsURL = map[string]string{
+	"govet":       "https://pkg.go.dev/cmd/vet",
+	"staticcheck": "https://staticcheck.dev/docs/checks/",
+	"gosimple":    "https://staticcheck.dev/docs/checks/",
+	"stylecheck":  "https://staticcheck.dev/docs/checks/",
+	"unused":      "https://github.com/dominikh/go-tools",
+	"errcheck":    "https://github.com/kisielk/errcheck",
+	"ineffassign": "https://github.com/gordonklaus/ineffassign",
+	"gosec":       "https://securego.io/docs/rules/rule-intro.html",
+	"gocritic":    "https://go-critic.com/overview.html",
+	"revive":      "https://revive.run/r",
+}
+
+// ruleCodeRe extracts a leading linter rule code (e.g. "SA1000" from
+// staticcheck, "G104" from gosec) from an issue's message.
+var ruleCodeRe = regexp.MustCompile(`^([A-Z]+[0-9]{3,4}):\s*(.+)$`)
+
+// relatedPosRe finds `file.go:line[:col]` references embedded in an
+// issue's message, as produced by e.g. govet's shadow checker or
+// ineffassign when pointing at another declaration.
+var relatedPosRe = regexp.MustCompile(`([\w./-]+\.go):(\d+)(?::(\d+))?`)
+
+// issueDiagnostic builds the full Diagnostic for issue, including its
+// range, rule code, documentation link, and any related positions the
+// linter's message points to.
+func (h *langHandler) issueDiagnostic(issue *Issue) Diagnostic {
+	d := Diagnostic{
+		Range:              issueRange(issue),
+		Severity:           issue.DiagSeverity(),
+		Source:             &issue.FromLinter,
+		Message:            h.diagnosticMessage(issue),
+		CodeDescription:    codeDescriptionForLinter(issue.FromLinter),
+		RelatedInformation: relatedInformation(issue),
+	}
+
+	if m := ruleCodeRe.FindStringSubmatch(issue.Text); m != nil {
+		d.Code = m[1]
+	}
+
+	return d
+}
+
+func codeDescriptionForLinter(linter string) *CodeDescription {
+	href, ok := linterDocsURL[linter]
+	if !ok {
+		return nil
+	}
+
+	return &CodeDescription{Href: href}
+}
+
+// relatedInformation looks for file:line[:col] references embedded in the
+// issue's own message and turns them into RelatedInformation entries
+// pointing at those locations.
+func relatedInformation(issue *Issue) []DiagnosticRelatedInformation {
+	matches := relatedPosRe.FindAllStringSubmatch(issue.Text, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	dir, _ := filepath.Split(issue.Pos.Filename)
+
+	related := make([]DiagnosticRelatedInformation, 0, len(matches))
+
+	for _, m := range matches {
+		line, err := strconv.Atoi(m[2])
+		if err != nil {
+			continue
+		}
+
+		col := 1
+		if m[3] != "" {
+			if c, err := strconv.Atoi(m[3]); err == nil {
+				col = c
+			}
+		}
+
+		path := m[1]
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+
+		related = append(related, DiagnosticRelatedInformation{
+			Location: Location{
+				URI: pathToURI(path),
+				Range: Range{
+					Start: Position{Line: max(line-1, 0), Character: max(col-1, 0)},
+					End:   Position{Line: max(line-1, 0), Character: max(col-1, 0)},
+				},
+			},
+			Message: issue.Text,
+		})
+	}
+
+	return related
+}
+
 func (h *langHandler) errToDiagnostics(err error) []Diagnostic {
 	var message string
 	switch e := err.(type) {
@@ -49,12 +272,21 @@ func (h *langHandler) errToDiagnostics(err error) []Diagnostic {
 		h.logger.DebugJSON("golangci-lint-langserver: errToDiagnostics message", message)
 		message = e.Error()
 	}
+
+	// These are hard failures (missing binary, unparseable output, config
+	// load errors) rather than ordinary lint findings, so make sure the
+	// user sees them even if they never look at the problems panel.
+	h.showMessage(MTError, message)
+
 	return []Diagnostic{
 		{Severity: DSError, Message: message},
 	}
 }
 
-func (h *langHandler) lint(uri DocumentURI) ([]Diagnostic, error) {
+// lint runs golangci-lint for uri and returns both the LSP diagnostics and
+// the raw issues reported for that file, the latter of which callers may
+// want to cache for later use (e.g. building code actions).
+func (h *langHandler) lint(ctx context.Context, uri DocumentURI) ([]Diagnostic, []Issue, error) {
 	diagnostics := make([]Diagnostic, 0)
 
 	path := uriToPath(string(uri))
@@ -63,7 +295,7 @@ func (h *langHandler) lint(uri DocumentURI) ([]Diagnostic, error) {
 	args := make([]string, 0, len(h.command))
 	args = append(args, h.command[1:]...)
 	args = append(args, dir)
-	cmd := exec.Command(h.command[0], args...)
+	cmd := exec.CommandContext(ctx, h.command[0], args...)
 
 	if strings.HasPrefix(path, h.rootDir) {
 		cmd.Dir = h.rootDir
@@ -74,44 +306,76 @@ func (h *langHandler) lint(uri DocumentURI) ([]Diagnostic, error) {
 	h.logger.DebugJSON("golangci-lint-langserver: golingci-lint cmd", cmd.String())
 
 	b, err := cmd.Output()
-	if err == nil {
-		return diagnostics, nil
+	if ctx.Err() != nil {
+		// The request was cancelled or superseded; drop the result rather
+		// than publishing diagnostics for a now-stale invocation.
+		return nil, nil, ctx.Err()
+	} else if err == nil {
+		return diagnostics, nil, nil
 	} else if len(b) == 0 {
 		// golangci-lint would output critical error to stderr rather than stdout
 		// https://github.com/nametake/golangci-lint-langserver/issues/24
-		return h.errToDiagnostics(err), nil
+		return h.errToDiagnostics(err), nil, nil
 	}
 
 	var result GolangCILintResult
 	if err := json.Unmarshal(b, &result); err != nil {
-		return h.errToDiagnostics(err), nil
+		return h.errToDiagnostics(err), nil, nil
 	}
 
 	h.logger.DebugJSON("golangci-lint-langserver: result:", result)
 
+	issues := make([]Issue, 0, len(result.Issues))
+
 	for _, issue := range result.Issues {
 		if path != issue.Pos.Filename {
 			continue
 		}
 
-		diagnostics = append(diagnostics, Diagnostic{
-			Range: Range{
-				Start: Position{
-					Line:      max(issue.Pos.Line-1, 0),
-					Character: max(issue.Pos.Column-1, 0),
-				},
-				End: Position{
-					Line:      max(issue.Pos.Line-1, 0),
-					Character: max(issue.Pos.Column-1, 0),
-				},
-			},
-			Severity: issue.DiagSeverity(),
-			Source:   &issue.FromLinter,
-			Message:  h.diagnosticMessage(&issue),
-		})
+		issues = append(issues, issue)
+		diagnostics = append(diagnostics, h.issueDiagnostic(&issue))
+	}
+
+	return diagnostics, issues, nil
+}
+
+// issueRange computes the LSP range an issue's quick fixes and diagnostics
+// should be anchored to. Rather than a zero-width marker at Pos, it spans to
+// the end of the offending line (or, for multi-line findings, the end of
+// the last line in LineRange) using the SourceLines golangci-lint reports
+// alongside the issue.
+func issueRange(issue *Issue) Range {
+	startLine := max(issue.Pos.Line-1, 0)
+	startCol := max(issue.Pos.Column-1, 0)
+
+	endLine := startLine
+	endCol := startCol
+
+	switch {
+	case issue.LineRange != nil && issue.LineRange.To > issue.LineRange.From:
+		endLine = max(issue.LineRange.To-1, startLine)
+		if n := len(issue.SourceLines); n > 0 {
+			endCol = len(issue.SourceLines[n-1])
+		}
+	case len(issue.SourceLines) > 0:
+		endCol = len(issue.SourceLines[0])
+	}
+
+	if endLine == startLine && endCol < startCol {
+		endCol = startCol
 	}
 
-	return diagnostics, nil
+	return Range{
+		Start: Position{Line: startLine, Character: startCol},
+		End:   Position{Line: endLine, Character: endCol},
+	}
+}
+
+// pathToURI converts an absolute filesystem path, as reported in an Issue's
+// Pos.Filename, into the DocumentURI golangci-lint-langserver uses
+// elsewhere. It's the inverse of uriToPath.
+func pathToURI(path string) DocumentURI {
+	return DocumentURI("file://" + path)
 }
 
 func (h *langHandler) diagnosticMessage(issue *Issue) string {
@@ -122,40 +386,613 @@ func (h *langHandler) diagnosticMessage(issue *Issue) string {
 	return fmt.Sprintf("%s: %s", issue.FromLinter, issue.Text)
 }
 
-func (h *langHandler) linter() {
-	for {
-		uri, ok := <-h.request
-		if !ok {
-			break
+// schedule debounces key (a URI, or workspaceLintKey for a workspace-wide
+// run): it cancels any previous timer/in-flight run for the same key and
+// schedules fn after h.debounce, so a burst of requests for the same key
+// results in a single golangci-lint invocation.
+func (h *langHandler) schedule(key DocumentURI, fn func(context.Context)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if p, ok := h.inflight[key]; ok {
+		p.timer.Stop()
+		p.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &pendingLint{cancel: cancel}
+	p.timer = time.AfterFunc(h.debounce, func() {
+		fn(ctx)
+
+		h.mu.Lock()
+		// Only clear our own entry: a newer schedule() call for key may have
+		// already replaced it with its own pendingLint while fn was running.
+		if h.inflight[key] == p {
+			delete(h.inflight, key)
 		}
+		h.mu.Unlock()
+	})
+	h.inflight[key] = p
+}
 
-		diagnostics, err := h.lint(uri)
-		if err != nil {
-			h.logger.Printf("%s\n", err)
+// scheduleLint debounces a per-file lint request for uri.
+func (h *langHandler) scheduleLint(uri DocumentURI) {
+	h.schedule(uri, func(ctx context.Context) {
+		h.runLint(ctx, uri)
+	})
+}
 
-			continue
+// scheduleWorkspaceLint debounces a whole-workspace lint run.
+func (h *langHandler) scheduleWorkspaceLint() {
+	h.schedule(workspaceLintKey, h.runWorkspaceLint)
+}
+
+// scheduleBufferLint debounces linting the in-memory buffer for uri.
+func (h *langHandler) scheduleBufferLint(uri DocumentURI) {
+	h.schedule(uri, func(ctx context.Context) {
+		h.runBufferLint(ctx, uri)
+	})
+}
+
+// applyContentChange applies a single TextDocumentContentChangeEvent to
+// text, returning the updated content.
+func applyContentChange(text string, change TextDocumentContentChangeEvent) string {
+	if change.Range == nil {
+		return change.Text
+	}
+
+	lines := strings.Split(text, "\n")
+	start := offsetForPosition(lines, change.Range.Start)
+	end := offsetForPosition(lines, change.Range.End)
+
+	return text[:start] + change.Text + text[end:]
+}
+
+// offsetForPosition converts an LSP Position into a byte offset into the
+// text represented by lines (text split on "\n").
+func offsetForPosition(lines []string, pos Position) int {
+	offset := 0
+
+	for i := 0; i < pos.Line && i < len(lines); i++ {
+		offset += len(lines[i]) + 1
+	}
+
+	if pos.Line < len(lines) {
+		offset += byteOffsetForUTF16Offset(lines[pos.Line], pos.Character)
+	}
+
+	return offset
+}
+
+// byteOffsetForUTF16Offset converts utf16Offset, an LSP Position.Character
+// (a UTF-16 code-unit count per the LSP spec), into a byte offset into
+// line. Runes outside the basic multilingual plane count as two UTF-16
+// code units, so this can't just be a byte or rune count.
+func byteOffsetForUTF16Offset(line string, utf16Offset int) int {
+	if utf16Offset <= 0 {
+		return 0
+	}
+
+	units := 0
+
+	for i, r := range line {
+		if units >= utf16Offset {
+			return i
 		}
 
-		if err := h.conn.Notify(
-			context.Background(),
-			"textDocument/publishDiagnostics",
-			&PublishDiagnosticsParams{
-				URI:         uri,
-				Diagnostics: diagnostics,
-			}); err != nil {
-			h.logger.Printf("%s\n", err)
+		if r > 0xFFFF {
+			units += 2
+		} else {
+			units++
+		}
+	}
+
+	return len(line)
+}
+
+func (h *langHandler) runLint(ctx context.Context, uri DocumentURI) {
+	diagnostics, issues, err := h.lint(ctx, uri)
+	if err != nil {
+		if ctx.Err() != nil {
+			// Superseded by a newer request for this URI; nothing to report.
+			return
+		}
+		h.logger.Printf("%s\n", err)
+
+		return
+	}
+
+	h.mu.Lock()
+	h.issues[uri] = issues
+	h.mu.Unlock()
+
+	h.publishDiagnostics(uri, diagnostics)
+}
+
+// runWorkspaceLint runs golangci-lint across the whole module, publishes
+// diagnostics grouped by file, and clears diagnostics for files that were
+// published by a previous run but no longer have issues.
+func (h *langHandler) runWorkspaceLint(ctx context.Context) {
+	args := make([]string, 0, len(h.command)+1)
+	args = append(args, h.command[1:]...)
+	args = append(args, "./...")
+	cmd := exec.CommandContext(ctx, h.command[0], args...)
+	cmd.Dir = h.rootDir
+
+	h.logger.DebugJSON("golangci-lint-langserver: golangci-lint workspace cmd", cmd.String())
+
+	b, err := cmd.Output()
+	if ctx.Err() != nil {
+		// Superseded by a newer workspace lint request; nothing to report.
+		return
+	}
+
+	var result GolangCILintResult
+
+	if err != nil && len(b) == 0 {
+		if e, ok := err.(*exec.ExitError); ok && e.ExitCode() == GoNoFilesExitCode {
+			// No packages matched ./... (e.g. an empty or non-Go workspace);
+			// same as lint()'s per-file case, this isn't worth bothering the
+			// user about.
+			return
+		}
+
+		// golangci-lint would output critical error to stderr rather than stdout
+		// https://github.com/nametake/golangci-lint-langserver/issues/24
+		h.errToDiagnostics(err)
+
+		return
+	} else if err == nil || len(b) > 0 {
+		if jsonErr := json.Unmarshal(b, &result); jsonErr != nil {
+			h.errToDiagnostics(jsonErr)
+
+			return
+		}
+	}
+
+	h.logger.DebugJSON("golangci-lint-langserver: workspace result:", result)
+
+	diagnosticsByURI := make(map[DocumentURI][]Diagnostic)
+	issuesByURI := make(map[DocumentURI][]Issue)
+
+	for _, issue := range result.Issues {
+		uri := pathToURI(issue.Pos.Filename)
+		issuesByURI[uri] = append(issuesByURI[uri], issue)
+		diagnosticsByURI[uri] = append(diagnosticsByURI[uri], h.issueDiagnostic(&issue))
+	}
+
+	h.mu.Lock()
+	stale := make([]DocumentURI, 0, len(h.published))
+	for uri := range h.published {
+		if _, ok := diagnosticsByURI[uri]; !ok {
+			stale = append(stale, uri)
+		}
+	}
+
+	for uri, issues := range issuesByURI {
+		h.issues[uri] = issues
+	}
+
+	for _, uri := range stale {
+		delete(h.issues, uri)
+	}
+
+	h.published = make(map[DocumentURI]bool, len(diagnosticsByURI))
+	for uri := range diagnosticsByURI {
+		h.published[uri] = true
+	}
+	h.mu.Unlock()
+
+	for _, uri := range stale {
+		h.publishDiagnostics(uri, []Diagnostic{})
+	}
+
+	for uri, diagnostics := range diagnosticsByURI {
+		h.publishDiagnostics(uri, diagnostics)
+	}
+}
+
+// runBufferLint lints the in-memory buffer for uri by writing it to a temp
+// file alongside the real file and running golangci-lint against just that
+// file. If golangci-lint can't meaningfully analyze a single file in
+// isolation (e.g. it reports nothing because type information from the
+// rest of the package is missing), it falls back to linting the package on
+// disk and reporting whatever still applies to uri.
+func (h *langHandler) runBufferLint(ctx context.Context, uri DocumentURI) {
+	h.mu.Lock()
+	state := h.buffers[uri]
+	h.mu.Unlock()
+
+	if state == nil {
+		return
+	}
+
+	origPath := uriToPath(string(uri))
+	dir, _ := filepath.Split(origPath)
+
+	tmpFile, err := os.CreateTemp(dir, "golangci-lint-langserver-*.go")
+	if err != nil {
+		h.logger.Printf("%s\n", err)
+
+		return
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+
+	_, writeErr := tmpFile.WriteString(state.text)
+	closeErr := tmpFile.Close()
+	if writeErr != nil {
+		h.logger.Printf("%s\n", writeErr)
+
+		return
+	} else if closeErr != nil {
+		h.logger.Printf("%s\n", closeErr)
+
+		return
+	}
+
+	issues, err := h.lintBuffer(ctx, tmpPath, dir)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
 		}
+
+		h.logger.Printf("%s\n", err)
+
+		return
+	}
+
+	if len(issues) == 0 {
+		// Remove the temp copy first so the package lint doesn't see the
+		// buffer's declarations twice.
+		os.Remove(tmpPath)
+
+		diagnostics, fallbackIssues, lintErr := h.lint(ctx, uri)
+		if lintErr != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			h.logger.Printf("%s\n", lintErr)
+
+			return
+		}
+
+		h.mu.Lock()
+		h.issues[uri] = fallbackIssues
+		h.mu.Unlock()
+
+		h.publishDiagnostics(uri, diagnostics)
+
+		return
+	}
+
+	diagnostics := make([]Diagnostic, 0, len(issues))
+
+	for i := range issues {
+		issues[i].Pos.Filename = origPath
+		diagnostics = append(diagnostics, h.issueDiagnostic(&issues[i]))
+	}
+
+	h.mu.Lock()
+	h.issues[uri] = issues
+	h.mu.Unlock()
+
+	h.publishDiagnostics(uri, diagnostics)
+}
+
+// lintBuffer runs golangci-lint against tmpPath (a temp file standing in
+// for the buffered document) and returns the issues golangci-lint reported
+// specifically against it, remapped to belong to the original document by
+// the caller.
+func (h *langHandler) lintBuffer(ctx context.Context, tmpPath, dir string) ([]Issue, error) {
+	args := make([]string, 0, len(h.command))
+	args = append(args, h.command[1:]...)
+	args = append(args, tmpPath)
+	cmd := exec.CommandContext(ctx, h.command[0], args...)
+	cmd.Dir = dir
+
+	h.logger.DebugJSON("golangci-lint-langserver: golangci-lint buffer cmd", cmd.String())
+
+	b, err := cmd.Output()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	} else if err != nil && len(b) == 0 {
+		return nil, err
+	}
+
+	var result GolangCILintResult
+	if len(b) > 0 {
+		if jsonErr := json.Unmarshal(b, &result); jsonErr != nil {
+			return nil, jsonErr
+		}
+	}
+
+	issues := make([]Issue, 0, len(result.Issues))
+
+	for _, issue := range result.Issues {
+		if issue.Pos.Filename == tmpPath {
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues, nil
+}
+
+func (h *langHandler) publishDiagnostics(uri DocumentURI, diagnostics []Diagnostic) {
+	if err := h.conn.Notify(
+		context.Background(),
+		"textDocument/publishDiagnostics",
+		&PublishDiagnosticsParams{
+			URI:         uri,
+			Diagnostics: diagnostics,
+		}); err != nil {
+		h.logger.Printf("%s\n", err)
+	}
+}
+
+// CancelParams is the payload of a `$/cancelRequest` notification.
+// https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#cancelRequest
+type CancelParams struct {
+	ID jsonrpc2.ID `json:"id"`
+}
+
+// fixAllCommand is the workspace/executeCommand name clients can bind to a
+// keybinding or menu entry to run golangci-lint --fix for a file.
+const fixAllCommand = "golangci-lint.fixAll"
+
+// CodeActionKindSourceFixAllGolangCILint is the kind used for the "fix all
+// issues in this file" code action, following the LSP convention of
+// namespacing source actions as `source.fixAll.<tool>`.
+const CodeActionKindSourceFixAllGolangCILint CodeActionKind = "source.fixAll.golangci-lint"
+
+type CodeActionKind string
+
+const CodeActionKindQuickFix CodeActionKind = "quickfix"
+
+type CodeActionParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	Range        Range                  `json:"range"`
+	Context      CodeActionContext      `json:"context"`
+}
+
+type CodeActionContext struct {
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+type TextDocumentIdentifier struct {
+	URI DocumentURI `json:"uri"`
+}
+
+type CodeAction struct {
+	Title       string         `json:"title"`
+	Kind        CodeActionKind `json:"kind,omitempty"`
+	Diagnostics []Diagnostic   `json:"diagnostics,omitempty"`
+	Edit        *WorkspaceEdit `json:"edit,omitempty"`
+	Command     *Command       `json:"command,omitempty"`
+}
+
+// Command is the LSP Command used to defer a CodeAction's effect until the
+// client actually invokes it, rather than computing it while merely
+// listing actions.
+type Command struct {
+	Title     string            `json:"title"`
+	Command   string            `json:"command"`
+	Arguments []json.RawMessage `json:"arguments,omitempty"`
+}
+
+type WorkspaceEdit struct {
+	Changes map[DocumentURI][]TextEdit `json:"changes,omitempty"`
+}
+
+type TextEdit struct {
+	Range   Range  `json:"range"`
+	NewText string `json:"newText"`
+}
+
+type CodeDescription struct {
+	Href string `json:"href"`
+}
+
+type Location struct {
+	URI   DocumentURI `json:"uri"`
+	Range Range       `json:"range"`
+}
+
+type DiagnosticRelatedInformation struct {
+	Location Location `json:"location"`
+	Message  string   `json:"message"`
+}
+
+type ApplyWorkspaceEditParams struct {
+	Edit WorkspaceEdit `json:"edit"`
+}
+
+// RegistrationParams is the payload of a client/registerCapability request.
+type RegistrationParams struct {
+	Registrations []Registration `json:"registrations"`
+}
+
+// Registration describes a single dynamic capability registration.
+type Registration struct {
+	ID              string `json:"id"`
+	Method          string `json:"method"`
+	RegisterOptions any    `json:"registerOptions,omitempty"`
+}
+
+// DidChangeWatchedFilesRegistrationOptions is the RegisterOptions for a
+// workspace/didChangeWatchedFiles registration.
+type DidChangeWatchedFilesRegistrationOptions struct {
+	Watchers []FileSystemWatcher `json:"watchers"`
+}
+
+// FileSystemWatcher describes a glob pattern the client should watch and
+// report changes for via workspace/didChangeWatchedFiles.
+type FileSystemWatcher struct {
+	GlobPattern string `json:"globPattern"`
+}
+
+const watchedFilesRegistrationID = "golangci-lint-langserver-watch-files"
+
+type ExecuteCommandOptions struct {
+	Commands []string `json:"commands"`
+}
+
+type ExecuteCommandParams struct {
+	Command   string            `json:"command"`
+	Arguments []json.RawMessage `json:"arguments,omitempty"`
+}
+
+type DidCloseTextDocumentParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+type VersionedTextDocumentIdentifier struct {
+	TextDocumentIdentifier
+	Version int `json:"version"`
+}
+
+type DidChangeTextDocumentParams struct {
+	TextDocument   VersionedTextDocumentIdentifier  `json:"textDocument"`
+	ContentChanges []TextDocumentContentChangeEvent `json:"contentChanges"`
+}
+
+// TextDocumentContentChangeEvent is either an incremental edit (Range set)
+// or a full-document replacement (Range nil, Text the whole new content).
+type TextDocumentContentChangeEvent struct {
+	Range       *Range `json:"range,omitempty"`
+	RangeLength int    `json:"rangeLength,omitempty"`
+	Text        string `json:"text"`
+}
+
+type DidChangeWatchedFilesParams struct {
+	Changes []FileEvent `json:"changes"`
+}
+
+type FileEvent struct {
+	URI  DocumentURI    `json:"uri"`
+	Type FileChangeType `json:"type"`
+}
+
+// FileChangeType mirrors the LSP FileChangeType enum.
+type FileChangeType int
+
+const (
+	FCTCreated FileChangeType = iota + 1
+	FCTChanged
+	FCTDeleted
+)
+
+// MessageType mirrors the LSP MessageType enum used by window/logMessage and
+// window/showMessage.
+// https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#messageType
+type MessageType int
+
+const (
+	MTError MessageType = iota + 1
+	MTWarning
+	MTInfo
+	MTLog
+)
+
+type LogMessageParams struct {
+	Type    MessageType `json:"type"`
+	Message string      `json:"message"`
+}
+
+type ShowMessageParams struct {
+	Type    MessageType `json:"type"`
+	Message string      `json:"message"`
+}
+
+// clientLogger wraps the base logger so that, once a client connection is
+// available, log lines are also forwarded to the client via
+// window/logMessage instead of only going to the server's own stderr.
+type clientLogger struct {
+	logger
+	conn     *jsonrpc2.Conn
+	logLevel MessageType
+	traceRPC bool
+}
+
+func newClientLogger(base logger, conn *jsonrpc2.Conn, logLevel MessageType, traceRPC bool) *clientLogger {
+	if logLevel == 0 {
+		logLevel = MTLog
+	}
+
+	return &clientLogger{logger: base, conn: conn, logLevel: logLevel, traceRPC: traceRPC}
+}
+
+func (l *clientLogger) Printf(format string, v ...any) {
+	l.logger.Printf(format, v...)
+	l.notify(MTError, fmt.Sprintf(format, v...))
+}
+
+func (l *clientLogger) DebugJSON(msg string, v any) {
+	l.logger.DebugJSON(msg, v)
+
+	if !l.traceRPC {
+		return
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+
+	l.notify(MTLog, fmt.Sprintf("%s %s", msg, b))
+}
+
+func (l *clientLogger) notify(level MessageType, message string) {
+	if l.conn == nil || level > l.logLevel {
+		return
+	}
+
+	if err := l.conn.Notify(context.Background(), "window/logMessage", &LogMessageParams{Type: level, Message: message}); err != nil {
+		l.logger.Printf("%s\n", err)
+	}
+}
+
+// showMessage notifies the client of a user-facing message via
+// window/showMessage. Unlike logMessage this is meant to be surfaced
+// prominently (e.g. as a toast), so it's reserved for actionable failures.
+func (h *langHandler) showMessage(level MessageType, message string) {
+	if h.conn == nil {
+		return
+	}
+
+	if err := h.conn.Notify(context.Background(), "window/showMessage", &ShowMessageParams{Type: level, Message: message}); err != nil {
+		h.logger.Printf("%s\n", err)
 	}
 }
 
 func (h *langHandler) handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) (result any, err error) {
 	h.logger.DebugJSON("golangci-lint-langserver: request:", req)
 
+	if req.Method == "$/cancelRequest" {
+		return h.handleCancelRequest(req)
+	}
+
+	if !req.Notif {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		h.mu.Lock()
+		h.cancels[req.ID] = cancel
+		h.mu.Unlock()
+
+		defer func() {
+			h.mu.Lock()
+			delete(h.cancels, req.ID)
+			h.mu.Unlock()
+			cancel()
+		}()
+	}
+
 	switch req.Method {
 	case "initialize":
 		return h.handleInitialize(ctx, conn, req)
 	case "initialized":
-		return
+		return h.handleInitialized(ctx, conn, req)
 	case "shutdown":
 		return h.handleShutdown(ctx, conn, req)
 	case "textDocument/didOpen":
@@ -166,13 +1003,40 @@ func (h *langHandler) handle(ctx context.Context, conn *jsonrpc2.Conn, req *json
 		return h.handleTextDocumentDidChange(ctx, conn, req)
 	case "textDocument/didSave":
 		return h.handleTextDocumentDidSave(ctx, conn, req)
+	case "textDocument/codeAction":
+		return h.handleTextDocumentCodeAction(ctx, conn, req)
 	case "workspace/didChangeConfiguration":
 		return h.handlerWorkspaceDidChangeConfiguration(ctx, conn, req)
+	case "workspace/didChangeWatchedFiles":
+		return h.handleWorkspaceDidChangeWatchedFiles(ctx, conn, req)
+	case "workspace/executeCommand":
+		return h.handleWorkspaceExecuteCommand(ctx, conn, req)
 	}
 
 	return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeMethodNotFound, Message: fmt.Sprintf("method not supported: %s", req.Method)}
 }
 
+// handleCancelRequest looks up the cancel func registered for req's ID and
+// invokes it. A cancel func is registered only once handle has started on a
+// request, so a cancel for a request that handle has not reached yet finds
+// nothing and is ignored.
+func (h *langHandler) handleCancelRequest(req *jsonrpc2.Request) (result any, err error) {
+	var params CancelParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, err
+	}
+
+	h.mu.Lock()
+	cancel, ok := h.cancels[params.ID]
+	h.mu.Unlock()
+
+	if ok {
+		cancel()
+	}
+
+	return nil, nil
+}
+
 func (h *langHandler) handleInitialize(_ context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) (result any, err error) {
 	var params InitializeParams
 	if err := json.Unmarshal(*req.Params, &params); err != nil {
@@ -183,20 +1047,77 @@ func (h *langHandler) handleInitialize(_ context.Context, conn *jsonrpc2.Conn, r
 	h.rootDir = uriToPath(params.RootURI)
 	h.conn = conn
 	h.command = params.InitializationOptions.Command
+	h.logger = newClientLogger(h.logger, conn, params.InitializationOptions.LogLevel, params.InitializationOptions.TraceRPC)
+
+	h.lintMode = params.InitializationOptions.LintMode
+	if h.lintMode == "" {
+		h.lintMode = LintModeWorkspace
+	}
+
+	h.incrementalSync = params.InitializationOptions.IncrementalSync
+
+	h.debounce = defaultDebounce
+	if ms := params.InitializationOptions.Debounce; ms > 0 {
+		h.debounce = time.Duration(ms) * time.Millisecond
+	}
+
+	change := TDSKNone
+	if h.incrementalSync {
+		change = TDSKIncremental
+	}
 
 	return InitializeResult{
 		Capabilities: ServerCapabilities{
 			TextDocumentSync: TextDocumentSyncOptions{
-				Change:    TDSKNone,
+				Change:    change,
 				OpenClose: true,
 				Save:      true,
 			},
+			CodeActionProvider: true,
+			ExecuteCommandProvider: &ExecuteCommandOptions{
+				Commands: []string{fixAllCommand},
+			},
 		},
 	}, nil
 }
 
+// handleInitialized kicks off the first workspace-wide lint run when the
+// server is configured for LintModeWorkspace, and registers a watcher for
+// Go sources and golangci-lint config so later edits made outside the
+// client's open documents (e.g. a save in another tool, a branch switch)
+// still trigger workspace/didChangeWatchedFiles and a re-lint.
+func (h *langHandler) handleInitialized(ctx context.Context, conn *jsonrpc2.Conn, _ *jsonrpc2.Request) (result any, err error) {
+	if h.lintMode == LintModeWorkspace {
+		if err := conn.Call(ctx, "client/registerCapability", &RegistrationParams{
+			Registrations: []Registration{
+				{
+					ID:     watchedFilesRegistrationID,
+					Method: "workspace/didChangeWatchedFiles",
+					RegisterOptions: DidChangeWatchedFilesRegistrationOptions{
+						Watchers: []FileSystemWatcher{
+							{GlobPattern: "**/*.go"},
+							{GlobPattern: "**/.golangci.yml"},
+						},
+					},
+				},
+			},
+		}, nil); err != nil {
+			h.logger.Printf("%s\n", err)
+		}
+
+		h.scheduleWorkspaceLint()
+	}
+
+	return nil, nil
+}
+
 func (h *langHandler) handleShutdown(_ context.Context, _ *jsonrpc2.Conn, _ *jsonrpc2.Request) (result any, err error) {
-	close(h.request)
+	h.mu.Lock()
+	for _, p := range h.inflight {
+		p.timer.Stop()
+		p.cancel()
+	}
+	h.mu.Unlock()
 
 	return nil, nil
 }
@@ -207,16 +1128,61 @@ func (h *langHandler) handleTextDocumentDidOpen(_ context.Context, _ *jsonrpc2.C
 		return nil, err
 	}
 
-	h.request <- params.TextDocument.URI
+	if h.incrementalSync {
+		h.mu.Lock()
+		h.buffers[params.TextDocument.URI] = &documentState{text: params.TextDocument.Text}
+		h.mu.Unlock()
+	}
+
+	if h.lintMode == LintModeFile {
+		h.scheduleLint(params.TextDocument.URI)
+	}
 
 	return nil, nil
 }
 
-func (h *langHandler) handleTextDocumentDidClose(_ context.Context, _ *jsonrpc2.Conn, _ *jsonrpc2.Request) (result any, err error) {
+func (h *langHandler) handleTextDocumentDidClose(_ context.Context, _ *jsonrpc2.Conn, req *jsonrpc2.Request) (result any, err error) {
+	if !h.incrementalSync {
+		return nil, nil
+	}
+
+	var params DidCloseTextDocumentParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, err
+	}
+
+	h.mu.Lock()
+	delete(h.buffers, params.TextDocument.URI)
+	h.mu.Unlock()
+
 	return nil, nil
 }
 
-func (h *langHandler) handleTextDocumentDidChange(_ context.Context, _ *jsonrpc2.Conn, _ *jsonrpc2.Request) (result any, err error) {
+func (h *langHandler) handleTextDocumentDidChange(_ context.Context, _ *jsonrpc2.Conn, req *jsonrpc2.Request) (result any, err error) {
+	if !h.incrementalSync {
+		return nil, nil
+	}
+
+	var params DidChangeTextDocumentParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, err
+	}
+
+	uri := params.TextDocument.URI
+
+	h.mu.Lock()
+	state, ok := h.buffers[uri]
+	if !ok {
+		state = &documentState{}
+		h.buffers[uri] = state
+	}
+	for _, change := range params.ContentChanges {
+		state.text = applyContentChange(state.text, change)
+	}
+	h.mu.Unlock()
+
+	h.scheduleBufferLint(uri)
+
 	return nil, nil
 }
 
@@ -226,7 +1192,9 @@ func (h *langHandler) handleTextDocumentDidSave(_ context.Context, _ *jsonrpc2.C
 		return nil, err
 	}
 
-	h.request <- params.TextDocument.URI
+	if h.lintMode == LintModeFile {
+		h.scheduleLint(params.TextDocument.URI)
+	}
 
 	return nil, nil
 }
@@ -234,3 +1202,237 @@ func (h *langHandler) handleTextDocumentDidSave(_ context.Context, _ *jsonrpc2.C
 func (h *langHandler) handlerWorkspaceDidChangeConfiguration(_ context.Context, _ *jsonrpc2.Conn, _ *jsonrpc2.Request) (result any, err error) {
 	return nil, nil
 }
+
+// handleWorkspaceDidChangeWatchedFiles re-runs the workspace lint when a Go
+// source file or the golangci-lint config changes on disk.
+func (h *langHandler) handleWorkspaceDidChangeWatchedFiles(_ context.Context, _ *jsonrpc2.Conn, req *jsonrpc2.Request) (result any, err error) {
+	if h.lintMode != LintModeWorkspace {
+		return nil, nil
+	}
+
+	var params DidChangeWatchedFilesParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, err
+	}
+
+	for _, change := range params.Changes {
+		path := uriToPath(string(change.URI))
+		if strings.HasSuffix(path, ".go") || strings.HasSuffix(path, ".golangci.yml") {
+			h.scheduleWorkspaceLint()
+
+			break
+		}
+	}
+
+	return nil, nil
+}
+
+// handleTextDocumentCodeAction offers a quickfix action for every cached
+// issue that carries a replacement and whose issueRange overlaps the
+// requested range, plus a
+// source.fixAll action bound to fixAllCommand. The fix-all action only
+// advertises that golangci-lint --fix has something to do; it's computed
+// lazily by workspace/executeCommand when the client actually invokes it,
+// since codeAction is commonly sent just to refresh the lightbulb and
+// must not have the side effect of rewriting the file on disk.
+func (h *langHandler) handleTextDocumentCodeAction(_ context.Context, _ *jsonrpc2.Conn, req *jsonrpc2.Request) (result any, err error) {
+	var params CodeActionParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, err
+	}
+
+	h.mu.Lock()
+	issues := h.issues[params.TextDocument.URI]
+	h.mu.Unlock()
+
+	actions := make([]CodeAction, 0, len(issues)+1)
+
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Replacement == nil || !rangesOverlap(params.Range, issueRange(issue)) {
+			continue
+		}
+
+		edit := workspaceEditForIssue(params.TextDocument.URI, issue)
+
+		actions = append(actions, CodeAction{
+			Title:       fmt.Sprintf("Fix: %s (%s)", issue.Text, issue.FromLinter),
+			Kind:        CodeActionKindQuickFix,
+			Diagnostics: []Diagnostic{h.issueDiagnostic(issue)},
+			Edit:        edit,
+		})
+	}
+
+	if hasFixableIssue(issues) {
+		uriArg, err := json.Marshal(params.TextDocument.URI)
+		if err != nil {
+			return nil, err
+		}
+
+		actions = append(actions, CodeAction{
+			Title: "Fix all golangci-lint issues in this file",
+			Kind:  CodeActionKindSourceFixAllGolangCILint,
+			Command: &Command{
+				Title:     "Fix all golangci-lint issues in this file",
+				Command:   fixAllCommand,
+				Arguments: []json.RawMessage{uriArg},
+			},
+		})
+	}
+
+	return actions, nil
+}
+
+// hasFixableIssue reports whether any issue carries a Replacement, i.e.
+// golangci-lint --fix would have something to do for this file.
+func hasFixableIssue(issues []Issue) bool {
+	for i := range issues {
+		if issues[i].Replacement != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rangesOverlap reports whether a and b share at least one position. A
+// zero-width range (e.g. an issue reported without SourceLines) overlaps a
+// request range that contains it.
+func rangesOverlap(a, b Range) bool {
+	return !positionLess(b.End, a.Start) && !positionLess(a.End, b.Start)
+}
+
+func positionLess(a, b Position) bool {
+	if a.Line != b.Line {
+		return a.Line < b.Line
+	}
+
+	return a.Character < b.Character
+}
+
+// workspaceEditForIssue turns a golangci-lint Replacement into the
+// WorkspaceEdit needed to apply it.
+func workspaceEditForIssue(uri DocumentURI, issue *Issue) *WorkspaceEdit {
+	startLine := max(issue.Pos.Line-1, 0)
+	endLine := startLine
+
+	if issue.LineRange != nil {
+		endLine = max(issue.LineRange.To-1, startLine)
+	}
+
+	var edit TextEdit
+
+	switch {
+	case issue.Replacement.Inline != nil:
+		inline := issue.Replacement.Inline
+		edit = TextEdit{
+			Range: Range{
+				Start: Position{Line: startLine, Character: inline.StartCol},
+				End:   Position{Line: startLine, Character: inline.StartCol + inline.Length},
+			},
+			NewText: inline.NewString,
+		}
+	default:
+		var newText string
+		if len(issue.Replacement.NewLines) > 0 {
+			newText = strings.Join(issue.Replacement.NewLines, "\n") + "\n"
+		}
+
+		edit = TextEdit{
+			Range: Range{
+				Start: Position{Line: startLine, Character: 0},
+				End:   Position{Line: endLine + 1, Character: 0},
+			},
+			NewText: newText,
+		}
+	}
+
+	return &WorkspaceEdit{Changes: map[DocumentURI][]TextEdit{uri: {edit}}}
+}
+
+// fixAll runs `golangci-lint run --fix` on the directory containing uri's
+// file, so other files in that directory may be rewritten on disk as well.
+// The returned WorkspaceEdit covers only uri's file: if its content changed,
+// a single edit replaces the whole file with the fixed content.
+func (h *langHandler) fixAll(ctx context.Context, uri DocumentURI) (*WorkspaceEdit, error) {
+	path := uriToPath(string(uri))
+	dir, _ := filepath.Split(path)
+
+	before, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, 0, len(h.command)+1)
+	args = append(args, h.command[1:]...)
+	args = append(args, "--fix", dir)
+	cmd := exec.CommandContext(ctx, h.command[0], args...)
+
+	if strings.HasPrefix(path, h.rootDir) {
+		cmd.Dir = h.rootDir
+	} else {
+		cmd.Dir = dir
+	}
+
+	h.logger.DebugJSON("golangci-lint-langserver: golangci-lint fix cmd", cmd.String())
+
+	if _, err := cmd.Output(); err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			return nil, err
+		}
+	}
+
+	after, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if string(before) == string(after) {
+		return &WorkspaceEdit{}, nil
+	}
+
+	lines := strings.Count(string(before), "\n") + 1
+
+	return &WorkspaceEdit{
+		Changes: map[DocumentURI][]TextEdit{
+			uri: {{
+				Range:   Range{Start: Position{Line: 0, Character: 0}, End: Position{Line: lines, Character: 0}},
+				NewText: string(after),
+			}},
+		},
+	}, nil
+}
+
+// handleWorkspaceExecuteCommand implements workspace/executeCommand for
+// fixAllCommand, applying the resulting edit via workspace/applyEdit since
+// executeCommand responses don't carry edits themselves.
+func (h *langHandler) handleWorkspaceExecuteCommand(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) (result any, err error) {
+	var params ExecuteCommandParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, err
+	}
+
+	if params.Command != fixAllCommand || len(params.Arguments) == 0 {
+		return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: fmt.Sprintf("unsupported command: %s", params.Command)}
+	}
+
+	var uri DocumentURI
+	if err := json.Unmarshal(params.Arguments[0], &uri); err != nil {
+		return nil, err
+	}
+
+	edit, err := h.fixAll(ctx, uri)
+	if err != nil {
+		h.logger.Printf("%s\n", err)
+
+		return nil, err
+	}
+
+	if err := conn.Call(ctx, "workspace/applyEdit", &ApplyWorkspaceEditParams{Edit: *edit}, nil); err != nil {
+		h.logger.Printf("%s\n", err)
+
+		return nil, err
+	}
+
+	return nil, nil
+}